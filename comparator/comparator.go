@@ -0,0 +1,129 @@
+// Package comparator provides the built-in three-way comparators used by
+// ordered collections such as juggler's OrderedContainer[T]. Every function
+// here follows the standard contract: negative if a < b, zero if a == b,
+// positive if a > b.
+package comparator
+
+// Signed is satisfied by the signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is satisfied by the unsigned integer types, including uintptr.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Int compares two signed integers of the same type.
+func Int[T Signed](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Uint compares two unsigned integers of the same type.
+func Uint[T Unsigned](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Float32 compares two float32 values. NaN is treated as less than every
+// non-NaN value and as equal to another NaN, so a sorted
+// OrderedContainer groups all NaNs at one end instead of producing an
+// inconsistent order.
+func Float32(a, b float32) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return -1
+	case bNaN:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Float64 compares two float64 values with the same NaN handling as Float32.
+func Float64(a, b float64) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return -1
+	case bNaN:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String compares two strings lexicographically by byte value.
+func String(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Bool compares two bools, treating false as less than true.
+func Bool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Complex64 compares two complex64 values by real part, then by imaginary
+// part when the real parts are equal.
+func Complex64(a, b complex64) int {
+	if c := Float32(real(a), real(b)); c != 0 {
+		return c
+	}
+	return Float32(imag(a), imag(b))
+}
+
+// Complex128 compares two complex128 values by real part, then by imaginary
+// part when the real parts are equal.
+func Complex128(a, b complex128) int {
+	if c := Float64(real(a), real(b)); c != 0 {
+		return c
+	}
+	return Float64(imag(a), imag(b))
+}
+
+// Reverse wraps cmp so that it orders items from greatest to least.
+func Reverse[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp(b, a)
+	}
+}
@@ -0,0 +1,40 @@
+package comparator
+
+import "testing"
+
+func TestInt(t *testing.T) {
+	if Int(1, 2) >= 0 {
+		t.Errorf("Int(1, 2) should be negative")
+	}
+	if Int(2, 1) <= 0 {
+		t.Errorf("Int(2, 1) should be positive")
+	}
+	if Int(1, 1) != 0 {
+		t.Errorf("Int(1, 1) should be zero")
+	}
+}
+
+func TestFloat64NaN(t *testing.T) {
+	nan := 0.0
+	nan /= nan
+
+	if Float64(nan, nan) != 0 {
+		t.Errorf("Float64(NaN, NaN) should be zero")
+	}
+	if Float64(nan, 1.0) >= 0 {
+		t.Errorf("Float64(NaN, 1.0) should be negative")
+	}
+	if Float64(1.0, nan) <= 0 {
+		t.Errorf("Float64(1.0, NaN) should be positive")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	rev := Reverse(Int[int])
+	if rev(1, 2) <= 0 {
+		t.Errorf("Reverse(Int)(1, 2) should be positive")
+	}
+	if rev(2, 1) >= 0 {
+		t.Errorf("Reverse(Int)(2, 1) should be negative")
+	}
+}
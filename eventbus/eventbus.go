@@ -0,0 +1,269 @@
+// Package eventbus provides a pluggable pub/sub subsystem. EventBus is
+// deliberately small so production code can swap the default in-process
+// Broker for a transport backed by NATS, Redis, or the bundled HTTPBroker
+// without touching callers.
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Handler processes a single event payload delivered on a topic.
+type Handler func(ctx context.Context, payload interface{}) error
+
+// Subscription represents a live registration returned by Subscribe; callers
+// use it to stop receiving events for that topic.
+type Subscription interface {
+	Unsubscribe()
+}
+
+// EventBus is the pub/sub contract wired into the HTTP and worker layers.
+type EventBus interface {
+	Publish(ctx context.Context, topic string, payload interface{}) error
+	Subscribe(topic string, handler Handler) (Subscription, error)
+	Close() error
+}
+
+// Broker is the default EventBus: an in-process fan-out implementation that
+// delivers each published event synchronously to every subscriber of its
+// topic.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[string][]*brokerSubscription
+	closed      bool
+}
+
+// NewBroker creates an empty in-process Broker.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[string][]*brokerSubscription)}
+}
+
+type brokerSubscription struct {
+	broker  *Broker
+	topic   string
+	handler Handler
+}
+
+func (s *brokerSubscription) Unsubscribe() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	subs := s.broker.subscribers[s.topic]
+	for i, sub := range subs {
+		if sub == s {
+			s.broker.subscribers[s.topic] = append(subs[:i], subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers payload to every subscriber of topic, in registration
+// order, stopping at the first handler error. Handlers run after the
+// subscriber list is snapshotted and the lock released, so a handler that
+// calls Subscribe or Unsubscribe on b doesn't deadlock.
+func (b *Broker) Publish(ctx context.Context, topic string, payload interface{}) error {
+	b.mu.RLock()
+	closed := b.closed
+	subs := append([]*brokerSubscription(nil), b.subscribers[topic]...)
+	b.mu.RUnlock()
+
+	if closed {
+		return errors.New("eventbus: broker is closed")
+	}
+	for _, sub := range subs {
+		if err := sub.handler(ctx, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe registers handler to receive every event published on topic.
+func (b *Broker) Subscribe(topic string, handler Handler) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, errors.New("eventbus: broker is closed")
+	}
+	sub := &brokerSubscription{broker: b, topic: topic, handler: handler}
+	b.subscribers[topic] = append(b.subscribers[topic], sub)
+	return sub, nil
+}
+
+// Close marks the broker closed; further Publish/Subscribe calls fail.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.subscribers = nil
+	return nil
+}
+
+// HTTPBroker is an EventBus backed by a remote broker: Publish POSTs the
+// event to Endpoint, and Subscribe long-polls Endpoint's /subscribe route
+// for deliveries on a background goroutine per topic.
+type HTTPBroker struct {
+	Endpoint string
+	Client   *http.Client
+
+	mu      sync.Mutex
+	topics  map[string][]httpHandlerEntry
+	pollers map[string]bool
+	nextID  int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	closed  bool
+}
+
+type httpHandlerEntry struct {
+	id      int
+	handler Handler
+}
+
+// NewHTTPBroker creates an HTTPBroker that publishes to and long-polls from
+// endpoint using http.DefaultClient.
+func NewHTTPBroker(endpoint string) *HTTPBroker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HTTPBroker{
+		Endpoint: endpoint,
+		Client:   http.DefaultClient,
+		topics:   make(map[string][]httpHandlerEntry),
+		pollers:  make(map[string]bool),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+type httpEventEnvelope struct {
+	Topic   string      `json:"topic"`
+	Payload interface{} `json:"payload"`
+}
+
+// Publish POSTs the event to Endpoint as a JSON envelope.
+func (b *HTTPBroker) Publish(ctx context.Context, topic string, payload interface{}) error {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return errors.New("eventbus: broker is closed")
+	}
+
+	body, err := json.Marshal(httpEventEnvelope{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("eventbus: publish to %s failed with status %d", b.Endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// Subscribe registers handler for topic and, if no long-poll goroutine is
+// already running for that topic, starts one against
+// Endpoint+"/subscribe" that keeps polling for deliveries until Close is
+// called.
+func (b *HTTPBroker) Subscribe(topic string, handler Handler) (Subscription, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, errors.New("eventbus: broker is closed")
+	}
+	id := b.nextID
+	b.nextID++
+	b.topics[topic] = append(b.topics[topic], httpHandlerEntry{id: id, handler: handler})
+	startPoller := !b.pollers[topic]
+	if startPoller {
+		b.pollers[topic] = true
+	}
+	b.mu.Unlock()
+
+	if startPoller {
+		go b.longPoll(topic)
+	}
+	return &httpSubscription{broker: b, topic: topic, id: id}, nil
+}
+
+// longPoll runs for the lifetime of the broker once started for topic,
+// regardless of how its handler count fluctuates; Subscribe never starts a
+// second one for the same topic, and Close's context cancellation is what
+// stops it.
+func (b *HTTPBroker) longPoll(topic string) {
+	for {
+		req, err := http.NewRequestWithContext(b.ctx, http.MethodGet, b.Endpoint+"/subscribe?topic="+url.QueryEscape(topic), nil)
+		if err != nil {
+			return
+		}
+
+		resp, err := b.Client.Do(req)
+		if err != nil {
+			select {
+			case <-b.ctx.Done():
+				return
+			default:
+				continue
+			}
+		}
+
+		var event httpEventEnvelope
+		if err := json.NewDecoder(resp.Body).Decode(&event); err == nil {
+			b.mu.Lock()
+			entries := append([]httpHandlerEntry(nil), b.topics[topic]...)
+			b.mu.Unlock()
+			for _, entry := range entries {
+				entry.handler(context.Background(), event.Payload)
+			}
+		}
+		resp.Body.Close()
+	}
+}
+
+// Close stops every long-poll goroutine by cancelling the context their
+// requests are bound to; further Publish/Subscribe calls fail.
+func (b *HTTPBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.cancel()
+	return nil
+}
+
+type httpSubscription struct {
+	broker *HTTPBroker
+	topic  string
+	id     int
+}
+
+func (s *httpSubscription) Unsubscribe() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	entries := s.broker.topics[s.topic]
+	for i, entry := range entries {
+		if entry.id == s.id {
+			s.broker.topics[s.topic] = append(entries[:i], entries[i+1:]...)
+			return
+		}
+	}
+}
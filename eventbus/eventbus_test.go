@@ -0,0 +1,155 @@
+package eventbus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBrokerPublishSubscribe(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	var got interface{}
+	if _, err := b.Subscribe("topic", func(ctx context.Context, payload interface{}) error {
+		got = payload
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := b.Publish(context.Background(), "topic", "hello"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got != "hello" {
+		t.Errorf("handler received %v, want %q", got, "hello")
+	}
+}
+
+// TestBrokerPublishHandlerUnsubscribesSelf is a regression test for Publish
+// deadlocking when a handler calls Unsubscribe (or Subscribe) on the same
+// broker.
+func TestBrokerPublishHandlerUnsubscribesSelf(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	var sub Subscription
+	var err error
+	sub, err = b.Subscribe("topic", func(ctx context.Context, payload interface{}) error {
+		sub.Unsubscribe()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Publish(context.Background(), "topic", "hello")
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Publish() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish() deadlocked when handler called Unsubscribe on itself")
+	}
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+
+	calls := 0
+	sub, err := b.Subscribe("topic", func(ctx context.Context, payload interface{}) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	sub.Unsubscribe()
+	if err := b.Publish(context.Background(), "topic", "hello"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("handler called %d times after Unsubscribe, want 0", calls)
+	}
+}
+
+// blockingHandlerServer returns a test server that never responds to
+// /subscribe requests, so a long-poll against it only returns once its
+// request context is cancelled.
+func blockingHandlerServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+}
+
+func goroutines() int {
+	runtime.Gosched()
+	return runtime.NumGoroutine()
+}
+
+func TestHTTPBrokerCloseStopsLongPoll(t *testing.T) {
+	srv := blockingHandlerServer()
+	defer srv.Close()
+
+	before := goroutines()
+
+	b := NewHTTPBroker(srv.URL)
+	if _, err := b.Subscribe("topic", func(ctx context.Context, payload interface{}) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// Give the long-poll goroutine time to start its request.
+	time.Sleep(50 * time.Millisecond)
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if goroutines() <= before {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("goroutine count = %d after Close, want <= %d (long-poll goroutine leaked)", goroutines(), before)
+}
+
+func TestHTTPBrokerResubscribeDoesNotStartSecondPoller(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	b := NewHTTPBroker(srv.URL)
+	defer b.Close()
+
+	sub, err := b.Subscribe("topic", func(ctx context.Context, payload interface{}) error { return nil })
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	sub.Unsubscribe()
+
+	before := goroutines()
+	if _, err := b.Subscribe("topic", func(ctx context.Context, payload interface{}) error { return nil }); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if after := goroutines(); after > before {
+		t.Errorf("goroutine count = %d after resubscribe, want <= %d (second poller started)", after, before)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBlockingGetDeadline(t *testing.T) {
+	c := NewContainer[int]()
+	c.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+
+	if _, err := c.BlockingGet(); err != ErrDeadlineExceeded {
+		t.Fatalf("BlockingGet() error = %v, want ErrDeadlineExceeded", err)
+	}
+}
+
+func TestBlockingAddGetRoundTrip(t *testing.T) {
+	c := NewContainer[int]()
+
+	if err := c.BlockingAdd(42); err != nil {
+		t.Fatalf("BlockingAdd() error = %v", err)
+	}
+
+	item, err := c.BlockingGet()
+	if err != nil {
+		t.Fatalf("BlockingGet() error = %v", err)
+	}
+	if item != 42 {
+		t.Errorf("BlockingGet() = %d, want 42", item)
+	}
+}
+
+func TestBlockingGetContextCancel(t *testing.T) {
+	c := NewContainer[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.BlockingGetContext(ctx); err != context.Canceled {
+		t.Fatalf("BlockingGetContext() error = %v, want context.Canceled", err)
+	}
+}
+
+// TestSetReadDeadlineChurn rapidly refreshes a near-future SetReadDeadline,
+// racing each call's Stop() against the previous deadline's AfterFunc
+// goroutine closing the shared cancel channel. It's a regression test for a
+// "close of closed channel" panic in setDeadline.
+func TestSetReadDeadlineChurn(t *testing.T) {
+	c := NewContainer[int]()
+	for i := 0; i < 300000; i++ {
+		c.SetReadDeadline(time.Now().Add(500 * time.Nanosecond))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"juggler/comparator"
+)
+
+func TestOrderedContainerInsertRange(t *testing.T) {
+	c := NewOrderedContainer[int](comparator.Int[int])
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		c.Insert(v)
+	}
+
+	got := c.Range(2, 4)
+	want := []int{2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("Range(2, 4) = %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got[i] != v {
+			t.Fatalf("Range(2, 4) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestOrderedContainerRemoveRank(t *testing.T) {
+	c := NewOrderedContainer[int](comparator.Int[int])
+	for _, v := range []int{10, 20, 30, 40} {
+		c.Insert(v)
+	}
+
+	if rank := c.Rank(30); rank != 2 {
+		t.Errorf("Rank(30) = %d, want 2", rank)
+	}
+
+	if !c.Remove(20) {
+		t.Fatalf("Remove(20) = false, want true")
+	}
+	if c.Remove(20) {
+		t.Errorf("Remove(20) second call = true, want false")
+	}
+
+	if rank := c.Rank(30); rank != 1 {
+		t.Errorf("Rank(30) after Remove(20) = %d, want 1", rank)
+	}
+	if size := c.Size(); size != 3 {
+		t.Errorf("Size() = %d, want 3", size)
+	}
+}
+
+func TestOrderedContainerMinMaxEmpty(t *testing.T) {
+	c := NewOrderedContainer[int](comparator.Int[int])
+
+	if _, err := c.Min(); err == nil {
+		t.Error("Min() on empty container returned nil error")
+	}
+	if _, err := c.Max(); err == nil {
+		t.Error("Max() on empty container returned nil error")
+	}
+
+	c.Insert(7)
+	c.Insert(3)
+	c.Insert(9)
+
+	if min, err := c.Min(); err != nil || min != 3 {
+		t.Errorf("Min() = (%d, %v), want (3, nil)", min, err)
+	}
+	if max, err := c.Max(); err != nil || max != 9 {
+		t.Errorf("Max() = (%d, %v), want (9, nil)", max, err)
+	}
+}
@@ -7,10 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"juggler/comparator"
+	"juggler/eventbus"
 )
 
 // Type aliases
@@ -108,10 +113,27 @@ type Numeric interface {
 	int | int32 | int64 | float32 | float64
 }
 
+const defaultContainerCapacity = 256
+
+// ErrDeadlineExceeded is returned by the blocking Container operations when
+// a deadline elapses first.
+var ErrDeadlineExceeded = errors.New("container: deadline exceeded")
+
 // Generic types
 type Container[T any] struct {
-	items []T
 	mu    sync.RWMutex
+	items []T
+	head  int
+	count int
+	cap   int
+
+	itemAvailable  chan struct{}
+	spaceAvailable chan struct{}
+
+	readCancel  chan struct{}
+	writeCancel chan struct{}
+	readTimer   *time.Timer
+	writeTimer  *time.Timer
 }
 
 type Result[T any, E error] struct {
@@ -219,39 +241,77 @@ func (r *Rectangle) SetColor(color string) {
 // Generic container methods
 func NewContainer[T any]() *Container[T] {
 	return &Container[T]{
-		items: make([]T, 0),
+		items:          make([]T, defaultContainerCapacity),
+		cap:            defaultContainerCapacity,
+		itemAvailable:  make(chan struct{}, 1),
+		spaceAvailable: make(chan struct{}, 1),
+		readCancel:     make(chan struct{}),
+		writeCancel:    make(chan struct{}),
 	}
 }
 
 func (c *Container[T]) Add(item T) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.items = append(c.items, item)
+	c.push(item)
+	c.mu.Unlock()
+	c.notify(c.itemAvailable)
+}
+
+// push writes item into the ring buffer, growing it first if it's full.
+// Callers must hold c.mu.
+func (c *Container[T]) push(item T) {
+	if c.count == c.cap {
+		c.grow()
+	}
+	c.items[(c.head+c.count)%c.cap] = item
+	c.count++
+}
+
+// grow doubles the ring buffer's capacity, re-linearizing existing items
+// starting at index 0. Callers must hold c.mu.
+func (c *Container[T]) grow() {
+	newCap := c.cap * 2
+	newItems := make([]T, newCap)
+	for i := 0; i < c.count; i++ {
+		newItems[i] = c.items[(c.head+i)%c.cap]
+	}
+	c.items = newItems
+	c.head = 0
+	c.cap = newCap
+}
+
+// notify signals ch without blocking if a receiver isn't already waiting.
+func (c *Container[T]) notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
 }
 
 func (c *Container[T]) Get(index int) (T, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	
+
 	var zero T
-	if index < 0 || index >= len(c.items) {
+	if index < 0 || index >= c.count {
 		return zero, errors.New("index out of range")
 	}
-	return c.items[index], nil
+	return c.items[(c.head+index)%c.cap], nil
 }
 
 func (c *Container[T]) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return len(c.items)
+	return c.count
 }
 
 func (c *Container[T]) Filter(predicate func(T) bool) []T {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	
+
 	var result []T
-	for _, item := range c.items {
+	for i := 0; i < c.count; i++ {
+		item := c.items[(c.head+i)%c.cap]
 		if predicate(item) {
 			result = append(result, item)
 		}
@@ -259,6 +319,279 @@ func (c *Container[T]) Filter(predicate func(T) bool) []T {
 	return result
 }
 
+// setDeadline arms or disarms the timer backing *cancel. Callers must hold
+// c.mu.
+func (c *Container[T]) setDeadline(timer **time.Timer, cancel *chan struct{}, t time.Time) {
+	if *timer != nil {
+		if !(*timer).Stop() {
+			// The timer's callback goroutine has already started; wait for
+			// it to finish closing *cancel before swapping it out, or we'd
+			// race a second close of the same channel.
+			<-*cancel
+		}
+		*timer = nil
+	}
+
+	select {
+	case <-*cancel:
+		*cancel = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		return
+	}
+
+	if !t.After(time.Now()) {
+		close(*cancel)
+		return
+	}
+
+	// Capture the cancel channel by value so a racing SetDeadline call that
+	// replaces *cancel doesn't cause this timer to close the new one.
+	ch := *cancel
+	*timer = time.AfterFunc(t.Sub(time.Now()), func() {
+		close(ch)
+	})
+}
+
+func (c *Container[T]) SetReadDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setDeadline(&c.readTimer, &c.readCancel, t)
+}
+
+func (c *Container[T]) SetWriteDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setDeadline(&c.writeTimer, &c.writeCancel, t)
+}
+
+// BlockingAdd adds item, blocking while the container is at capacity until
+// room frees up or the write deadline elapses.
+func (c *Container[T]) BlockingAdd(item T) error {
+	return c.BlockingAddContext(context.Background(), item)
+}
+
+// BlockingAddContext is BlockingAdd, additionally giving up with ctx.Err()
+// once ctx is done.
+func (c *Container[T]) BlockingAddContext(ctx context.Context, item T) error {
+	for {
+		c.mu.Lock()
+		if c.count < c.cap {
+			c.push(item)
+			c.mu.Unlock()
+			c.notify(c.itemAvailable)
+			return nil
+		}
+		writeCancel := c.writeCancel
+		c.mu.Unlock()
+
+		select {
+		case <-c.spaceAvailable:
+		case <-writeCancel:
+			return ErrDeadlineExceeded
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// BlockingGet removes and returns the oldest item, blocking while the
+// container is empty until an item arrives or the read deadline elapses.
+func (c *Container[T]) BlockingGet() (T, error) {
+	return c.BlockingGetContext(context.Background())
+}
+
+// BlockingGetContext is BlockingGet, additionally giving up with ctx.Err()
+// once ctx is done.
+func (c *Container[T]) BlockingGetContext(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		c.mu.Lock()
+		if c.count > 0 {
+			item := c.items[c.head]
+			c.head = (c.head + 1) % c.cap
+			c.count--
+			c.mu.Unlock()
+			c.notify(c.spaceAvailable)
+			return item, nil
+		}
+		readCancel := c.readCancel
+		c.mu.Unlock()
+
+		select {
+		case <-c.itemAvailable:
+		case <-readCancel:
+			return zero, ErrDeadlineExceeded
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Comparator reports the three-way order of a and b: negative if a < b, zero
+// if equal, positive if a > b.
+type Comparator[T any] func(a, b T) int
+
+const maxSkipListLevel = 16
+
+type skipListNode[T any] struct {
+	value T
+	next  []*skipListNode[T]
+}
+
+// OrderedContainer is the sorted counterpart to Container[T]: items are kept
+// in comparator order in a skip list.
+type OrderedContainer[T any] struct {
+	mu    sync.RWMutex
+	cmp   Comparator[T]
+	head  *skipListNode[T]
+	level int
+	size  int
+	rnd   *rand.Rand
+}
+
+// NewOrderedContainer creates an empty OrderedContainer ordered by cmp.
+func NewOrderedContainer[T any](cmp Comparator[T]) *OrderedContainer[T] {
+	return &OrderedContainer[T]{
+		cmp:   cmp,
+		head:  &skipListNode[T]{next: make([]*skipListNode[T], maxSkipListLevel)},
+		level: 1,
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (c *OrderedContainer[T]) randomLevel() int {
+	level := 1
+	for level < maxSkipListLevel && c.rnd.Float64() < 0.5 {
+		level++
+	}
+	return level
+}
+
+func (c *OrderedContainer[T]) Insert(item T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	update := make([]*skipListNode[T], maxSkipListLevel)
+	node := c.head
+	for i := c.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && c.cmp(node.next[i].value, item) < 0 {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+
+	level := c.randomLevel()
+	if level > c.level {
+		for i := c.level; i < level; i++ {
+			update[i] = c.head
+		}
+		c.level = level
+	}
+
+	newNode := &skipListNode[T]{value: item, next: make([]*skipListNode[T], level)}
+	for i := 0; i < level; i++ {
+		newNode.next[i] = update[i].next[i]
+		update[i].next[i] = newNode
+	}
+	c.size++
+}
+
+func (c *OrderedContainer[T]) Remove(item T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	update := make([]*skipListNode[T], maxSkipListLevel)
+	node := c.head
+	for i := c.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && c.cmp(node.next[i].value, item) < 0 {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+
+	target := node.next[0]
+	if target == nil || c.cmp(target.value, item) != 0 {
+		return false
+	}
+
+	for i := 0; i < c.level; i++ {
+		if update[i].next[i] == target {
+			update[i].next[i] = target.next[i]
+		}
+	}
+	for c.level > 1 && c.head.next[c.level-1] == nil {
+		c.level--
+	}
+	c.size--
+	return true
+}
+
+func (c *OrderedContainer[T]) Min() (T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var zero T
+	if c.head.next[0] == nil {
+		return zero, errors.New("ordered container is empty")
+	}
+	return c.head.next[0].value, nil
+}
+
+func (c *OrderedContainer[T]) Max() (T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var zero T
+	node := c.head
+	for i := c.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+	if node == c.head {
+		return zero, errors.New("ordered container is empty")
+	}
+	return node.value, nil
+}
+
+func (c *OrderedContainer[T]) Rank(item T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rank := 0
+	for node := c.head.next[0]; node != nil && c.cmp(node.value, item) < 0; node = node.next[0] {
+		rank++
+	}
+	return rank
+}
+
+func (c *OrderedContainer[T]) Range(lo, hi T) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	node := c.head
+	for i := c.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && c.cmp(node.next[i].value, lo) < 0 {
+			node = node.next[i]
+		}
+	}
+
+	var result []T
+	for node = node.next[0]; node != nil && c.cmp(node.value, hi) <= 0; node = node.next[0] {
+		result = append(result, node.value)
+	}
+	return result
+}
+
+func (c *OrderedContainer[T]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.size
+}
+
 // Generic functions
 func Max[T Numeric](a, b T) T {
 	if a > b {
@@ -366,35 +699,61 @@ func Multiplier(factor int) func(int) int {
 }
 
 // Channel and goroutine functions
-func Worker(jobs <-chan int, results chan<- int) {
-	for job := range jobs {
-		// Simulate work
-		time.Sleep(time.Millisecond * 100)
-		results <- job * 2
+func Worker(ctx context.Context, jobs <-chan int, results chan<- int) {
+	for {
+		select {
+		case job, ok := <-jobs:
+			if !ok {
+				return
+			}
+			// Simulate work
+			select {
+			case <-time.After(time.Millisecond * 100):
+			case <-ctx.Done():
+				return
+			}
+			doubled := job * 2
+			if err := bus().Publish(ctx, "job.completed", doubled); err != nil {
+				log.Printf("eventbus: publish job.completed: %v", err)
+			}
+			select {
+			case results <- doubled:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
-func ProcessConcurrently(data []int, workers int) []int {
+func ProcessConcurrently(ctx context.Context, data []int, workers int) []int {
 	jobs := make(chan int, len(data))
 	results := make(chan int, len(data))
-	
+
 	// Start workers
 	for w := 0; w < workers; w++ {
-		go Worker(jobs, results)
+		go Worker(ctx, jobs, results)
 	}
-	
+
 	// Send jobs
 	for _, value := range data {
 		jobs <- value
 	}
 	close(jobs)
-	
-	// Collect results
+
+	// Collect results; stop cleanly instead of deadlocking if ctx is
+	// cancelled before every result has arrived.
 	var processed []int
 	for i := 0; i < len(data); i++ {
-		processed = append(processed, <-results)
+		select {
+		case result := <-results:
+			processed = append(processed, result)
+		case <-ctx.Done():
+			return processed
+		}
 	}
-	
+
 	return processed
 }
 
@@ -419,10 +778,49 @@ func ProcessWithTimeout(ctx context.Context, data []int) ([]int, error) {
 	case err := <-errorChan:
 		return nil, err
 	case <-ctx.Done():
+		if err := bus().Publish(context.Background(), "process.cancelled", ctx.Err()); err != nil {
+			log.Printf("eventbus: publish process.cancelled: %v", err)
+		}
 		return nil, ctx.Err()
 	}
 }
 
+// Options configures optional subsystems used by the HTTP handlers and
+// worker pool. The zero value leaves the default in-process EventBus in
+// place.
+type Options struct {
+	Bus eventbus.EventBus
+}
+
+// defaultBus holds the eventbus.EventBus used by createUserHandler, Worker,
+// and ProcessWithTimeout. It's an atomic.Value rather than a plain variable
+// because Configure can run concurrently with handlers and workers already
+// reading it.
+var defaultBus atomic.Value
+
+// busHolder gives defaultBus one concrete type to store, as atomic.Value
+// requires, whichever EventBus implementation it wraps.
+type busHolder struct {
+	bus eventbus.EventBus
+}
+
+func init() {
+	defaultBus.Store(busHolder{bus: eventbus.NewBroker()})
+}
+
+// bus returns the currently configured event bus.
+func bus() eventbus.EventBus {
+	return defaultBus.Load().(busHolder).bus
+}
+
+// Configure installs opts, swapping in opts.Bus in place of the default
+// in-process broker.
+func Configure(opts Options) {
+	if opts.Bus != nil {
+		defaultBus.Store(busHolder{bus: opts.Bus})
+	}
+}
+
 // HTTP handler functions
 func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	response := map[string]interface{}{
@@ -463,7 +861,11 @@ func createUserHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	
+
+	if err := bus().Publish(r.Context(), "user.created", user); err != nil {
+		log.Printf("eventbus: publish user.created: %v", err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(user)
@@ -488,6 +890,31 @@ func InspectType(v interface{}) {
 	}
 }
 
+// DispatchFieldMethod calls target's "Set<Field>" method for each field of
+// payload whose name and type match one, e.g. a Color field calls SetColor.
+func DispatchFieldMethod(target interface{}, payload interface{}) {
+	payloadType := reflect.TypeOf(payload)
+	if payloadType.Kind() != reflect.Struct {
+		return
+	}
+	payloadValue := reflect.ValueOf(payload)
+	targetValue := reflect.ValueOf(target)
+
+	for i := 0; i < payloadType.NumField(); i++ {
+		field := payloadType.Field(i)
+		method := targetValue.MethodByName("Set" + field.Name)
+		if !method.IsValid() || method.Type().NumIn() != 1 {
+			continue
+		}
+
+		fieldValue := payloadValue.Field(i)
+		if !fieldValue.Type().AssignableTo(method.Type().In(0)) {
+			continue
+		}
+		method.Call([]reflect.Value{fieldValue})
+	}
+}
+
 // Main function
 func main() {
 	LogMessage("INFO", "Starting %s version %s", AppName, Version)
@@ -521,7 +948,19 @@ func main() {
 		shape.Move(1, 1)
 		fmt.Printf("New position: %v\n", shape.GetPosition())
 	}
-	
+
+	// Test eventbus wiring: a shape.repaint subscriber dispatches its Color
+	// field to SetColor via reflection.
+	type shapeRepaintEvent struct {
+		Color string
+	}
+	bus().Subscribe("shape.repaint", func(ctx context.Context, payload interface{}) error {
+		DispatchFieldMethod(&circle, payload)
+		return nil
+	})
+	bus().Publish(context.Background(), "shape.repaint", shapeRepaintEvent{Color: "green"})
+	fmt.Printf("Circle color after shape.repaint: %s\n", circle.GetColor())
+
 	// Test generics
 	container := NewContainer[string]()
 	container.Add("hello")
@@ -529,11 +968,22 @@ func main() {
 	container.Add("go")
 	
 	fmt.Printf("Container size: %d\n", container.Size())
-	
+
 	filtered := container.Filter(func(s string) bool {
 		return len(s) > 2
 	})
 	fmt.Printf("Filtered items: %v\n", filtered)
+
+	// Test ordered container
+	ordered := NewOrderedContainer[int](comparator.Int[int])
+	ordered.Insert(5)
+	ordered.Insert(1)
+	ordered.Insert(3)
+	ordered.Insert(9)
+	min, _ := ordered.Min()
+	max, _ := ordered.Max()
+	fmt.Printf("Ordered container range: %v, min: %d, max: %d, rank(5): %d\n",
+		ordered.Range(1, 9), min, max, ordered.Rank(5))
 	
 	// Test generic functions
 	numbers := []int{1, 2, 3, 4, 5}
@@ -555,8 +1005,22 @@ func main() {
 	
 	// Test concurrent processing
 	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	processed := ProcessConcurrently(data, 3)
+	processed := ProcessConcurrently(context.Background(), data, 3)
 	fmt.Printf("Processed concurrently: %v\n", processed)
+
+	// Test deadline-aware blocking container operations
+	blocking := NewContainer[int]()
+	blocking.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, err := blocking.BlockingGet(); err != nil {
+		fmt.Printf("BlockingGet on empty container: %v\n", err)
+	}
+	blocking.SetReadDeadline(time.Time{})
+	if err := blocking.BlockingAdd(42); err != nil {
+		fmt.Printf("BlockingAdd error: %v\n", err)
+	}
+	if item, err := blocking.BlockingGet(); err == nil {
+		fmt.Printf("BlockingGet item: %d\n", item)
+	}
 	
 	// Test context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)